@@ -5,13 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/build/constraint"
+	"go/format"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"os"
 	"os/exec"
 	"path"
 	"strconv"
 	"strings"
+
+	"golang.org/x/tools/imports"
 )
 
 // executeCommand splits the input command string, executes it, and prints any error to stderr.
@@ -30,6 +35,87 @@ func executeCommand(command string) {
 	}
 }
 
+// EscapeMode selects which utils escaper TypeHTML/TypeEscape blocks are routed through, mirroring the split that
+// text/template vs. html/template provide in the standard library for non-HTML output.
+type EscapeMode int
+
+const (
+	// EscapeHTML calls Goh.EscapeHTML, the default for HTML templates.
+	EscapeHTML EscapeMode = iota
+	// EscapeJS calls Goh.EscapeJS, for values embedded in a JavaScript string literal.
+	EscapeJS
+	// EscapeURL calls Goh.EscapeURL, for values embedded in a URL query.
+	EscapeURL
+	// EscapeCSV calls Goh.EscapeCSVField, for values embedded in a CSV row.
+	EscapeCSV
+	// EscapeNone calls Goh.EscapeText, a no-op passthrough for plain-text output.
+	EscapeNone
+)
+
+// funcName returns the name of the utils function that implements this escaper.
+func (e EscapeMode) funcName() string {
+	switch e {
+	case EscapeJS:
+		return "EscapeJS"
+	case EscapeURL:
+		return "EscapeURL"
+	case EscapeCSV:
+		return "EscapeCSVField"
+	case EscapeNone:
+		return "EscapeText"
+	default:
+		return "EscapeHTML"
+	}
+}
+
+// parseEscapeMode maps a {{! mode: ...}} directive's mode name to an EscapeMode.
+func parseEscapeMode(name string) (EscapeMode, bool) {
+	switch name {
+	case "html":
+		return EscapeHTML, true
+	case "js":
+		return EscapeJS, true
+	case "url":
+		return EscapeURL, true
+	case "csv":
+		return EscapeCSV, true
+	case "text":
+		return EscapeNone, true
+	default:
+		return EscapeHTML, false
+	}
+}
+
+// SinkKind identifies the kind of value the last parameter of a template function writes generated output to.
+type SinkKind int
+
+const (
+	// SinkUnspecified is the CodeGenerator.SinkType zero value, meaning the caller left the sink to be
+	// inferred from the function signature rather than asserting one up front.
+	SinkUnspecified SinkKind = iota
+	// SinkBuffer targets a *bytes.Buffer last parameter.
+	SinkBuffer
+	// SinkWriter targets an io.Writer last parameter. Since io.Writer offers no Grow, no preallocation
+	// prelude is emitted, and output is routed through io.WriteString and the utils *W helpers.
+	SinkWriter
+	// SinkStringsBuilder targets a *strings.Builder last parameter.
+	SinkStringsBuilder
+)
+
+// String returns the sink's parameter type as it would appear in a Go signature, for error messages.
+func (s SinkKind) String() string {
+	switch s {
+	case SinkBuffer:
+		return "*bytes.Buffer"
+	case SinkWriter:
+		return "io.Writer"
+	case SinkStringsBuilder:
+		return "*strings.Builder"
+	default:
+		return "unspecified"
+	}
+}
+
 // CodeGenerator is a struct for generating Go code from parsed template blocks.
 type CodeGenerator struct {
 	DefinedFunction *Block
@@ -41,18 +127,38 @@ type CodeGenerator struct {
 	RawCode         string
 	PackageName     string
 	Destination     string
+	TemplateDir     string
+	TemplatePath    string
+	SinkType        SinkKind
+	ContextAware    bool
+	ContextName     string
+	BuildConstraint string
+	Escaper         EscapeMode
+	escaper         EscapeMode
 }
 
 // NewGenerator initializes a new code generator, parses the given template file, and sets up the necessary fields for code generation.
-func (g *CodeGenerator) NewGenerator(templateFilePath string) {
+// It returns an error instead of panicking so that callers can distinguish an invalid template (parse error)
+// from invalid generated code (format/build error).
+func (g *CodeGenerator) NewGenerator(templateFilePath string) error {
 	if g.PackageName == "" {
 		g.PackageName = "template"
 	}
 	g.Buffer = bytes.NewBuffer(nil)
+	g.TemplateDir = path.Dir(templateFilePath)
+	g.TemplatePath = templateFilePath
 
 	// Parse the template file using Parser
 	blocks, rawCode, definedFunc := (&Parser{}).Parse(templateFilePath)
 
+	// Resolve {{extend "base"}} directives into a single, merged block stream before generating code. The
+	// render function comes from whichever template defines one, so a child with no {{func}} of its own
+	// inherits the base layout's.
+	blocks, rawCode, definedFunc, err := (&Resolver{TemplateDir: g.TemplateDir}).Resolve(blocks, rawCode, definedFunc, templateFilePath)
+	if err != nil {
+		return err
+	}
+
 	// Directly assign the parsed data to the expected types
 	g.RootBlocks = blocks
 	g.RawCode = rawCode
@@ -60,54 +166,257 @@ func (g *CodeGenerator) NewGenerator(templateFilePath string) {
 
 	outputFile, err := os.Create(path.Join(g.Destination, path.Base(templateFilePath)+".go"))
 	if err != nil {
-		panic(err.Error())
+		return err
 	}
-	outputFile.WriteString("// DO NOT EDIT!\n// Generated by Goh\n\n")
 	g.OutputFile = outputFile
-	g.GenerateCode()
+
+	if err := g.GenerateCode(); err != nil {
+		return err
+	}
 	fmt.Println("\033[0;32mSuccess\033[0m", templateFilePath)
+	return nil
 }
 
-// GenerateCode writes the generated Go code to the output file based on the parsed template and defined function.
-func (g *CodeGenerator) GenerateCode() {
-	g.OutputFile.WriteString("package ")
-	g.OutputFile.WriteString(g.PackageName)
-	g.OutputFile.WriteString("\nimport (\n\t\"bytes\"\n\t\"github.com/OblivionOcean/Goh/utils\"\n)\n\n")
+// GenerateCode assembles the generated Go code in memory, runs it through go/format and goimports, and writes the
+// result to the output file. If formatting fails, the unformatted source is written anyway and the formatting
+// error is returned to the caller, so a broken template never silently produces no output.
+func (g *CodeGenerator) GenerateCode() error {
+	buildLine, err := g.resolveBuildConstraint()
+	if err != nil {
+		return err
+	}
+	g.escaper = g.resolveEscaper()
+
+	var code, bufferName string
+	if g.DefinedFunction != nil {
+		code, bufferName, err = g.generateFunction(g.DefinedFunction)
+		if err != nil {
+			return err
+		}
+		g.BufferName = bufferName
+	}
+
+	var source bytes.Buffer
+	source.WriteString("// DO NOT EDIT!\n// Generated by Goh\n\n")
+	if buildLine != "" {
+		source.WriteString(buildLine)
+		source.WriteString("\n\n")
+	}
+	source.WriteString("package ")
+	source.WriteString(g.PackageName)
+	source.WriteString("\nimport (\n")
+	for _, imp := range g.sinkImports() {
+		source.WriteString("\t\"")
+		source.WriteString(imp)
+		source.WriteString("\"\n")
+	}
+	source.WriteString("\t\"github.com/OblivionOcean/Goh/utils\"\n)\n\n")
 
 	if g.DefinedFunction == nil {
-		return
+		return g.writeOutput(source.Bytes())
+	}
+
+	source.WriteString(g.RawCode)
+	source.WriteString(code)
+	source.WriteString("{\n")
+	if g.SinkType != SinkWriter {
+		source.WriteString(fmt.Sprintf("%s.Grow(", bufferName))
+	}
+
+	g.renderBlocks(g.RootBlocks)
+
+	if g.SinkType != SinkWriter {
+		source.WriteString(strconv.Itoa(g.ConstantLength))
+		source.WriteString(")\n")
 	}
+	source.Write(g.Buffer.Bytes())
+	if g.ContextName != "" {
+		source.WriteString("return nil\n")
+	}
+	source.WriteString("}\n")
+
+	return g.writeOutput(source.Bytes())
+}
 
-	code, bufferName, err := g.generateFunction(g.DefinedFunction)
+// writeOutput formats src with go/format and goimports before writing it to OutputFile. Imports added by
+// user code inside {{ }} blocks are resolved automatically by the goimports pass. If formatting fails, the
+// unformatted source is still written so the caller can inspect it, and the formatting error is returned.
+func (g *CodeGenerator) writeOutput(src []byte) error {
+	formatted, err := format.Source(src)
 	if err != nil {
-		panic(err.Error())
+		g.OutputFile.Write(src)
+		return err
+	}
+
+	if processed, err := imports.Process("", formatted, nil); err == nil {
+		formatted = processed
 	}
-	g.BufferName = bufferName
-	g.OutputFile.WriteString(g.RawCode)
-	g.OutputFile.WriteString(code)
-	g.OutputFile.WriteString(fmt.Sprintf("{\n%s.Grow(", bufferName))
 
-	for i := 0; i < len(g.RootBlocks); i++ {
-		block := g.RootBlocks[i]
+	_, err = g.OutputFile.Write(formatted)
+	return err
+}
+
+// renderBlocks walks blocks in order, writing the generated code for each one to g.Buffer. A TypeBlock region
+// left over after Resolver.Resolve has run its substitutions is transparent at generation time: its children
+// render exactly as if they appeared inline, which is what lets an unextended template's own {{block}} regions
+// act as plain content. A TypeExtend directive has already been consumed by the resolver by the time generation
+// runs, so it is skipped defensively rather than treated as an error.
+//
+// When the template function is context-aware (g.ContextName set), a cancellation guard is emitted between
+// top-level blocks (depth 0) and as the first statement inside a {{for ...}} loop's body, so a long-running
+// range is still interruptible. depth is tracked by counting braces in the raw Go snippets carried by TypeCode
+// blocks, which is what lets the guard skip over non-loop control blocks (if/switch/select) emitted the same
+// way: inserting it right after "switch {" but before the first "case" would not compile.
+func (g *CodeGenerator) renderBlocks(blocks Blocks) {
+	depth := 0
+	for _, block := range blocks {
 		switch block.BlockType {
 		case TypeCode:
 			g.Buffer.WriteString(block.Content)
 			g.Buffer.WriteString("\n")
+
+			loopEntry := isLoopEntry(block.Content)
+			depth += strings.Count(block.Content, "{") - strings.Count(block.Content, "}")
+			if g.ContextName != "" && loopEntry && depth > 0 {
+				g.writeContextGuard()
+			}
+			continue
 		case TypeHTML:
 			g.generateEscapedHTML(block)
 		case TypeEscape:
 			g.generateEscapedHTML(block)
 		case TypeValue:
 			g.generateValue(block)
-		case TypeExtend:
+		case TypeBlock:
+			g.renderBlocks(block.Children)
+		case TypeExtend, TypeBuild, TypeMode:
 			continue
 		}
+
+		if g.ContextName != "" && depth == 0 {
+			g.writeContextGuard()
+		}
 	}
+}
 
-	g.OutputFile.WriteString(strconv.Itoa(g.ConstantLength))
-	g.OutputFile.WriteString(")\n")
-	g.OutputFile.ReadFrom(g.Buffer)
-	g.OutputFile.WriteString("}\n")
+// writeContextGuard emits a cancellation check against the template function's context.Context parameter.
+func (g *CodeGenerator) writeContextGuard() {
+	fmt.Fprintf(g.Buffer, "if err := %s.Err(); err != nil {\n\treturn err\n}\n", g.ContextName)
+}
+
+// isLoopEntry reports whether code is a raw Go snippet that opens a for loop, e.g. "for _, v := range xs {" or
+// "for {". It deliberately does not match "switch {"/"select {", since those are not loop boundaries.
+func isLoopEntry(code string) bool {
+	trimmed := strings.TrimSpace(code)
+	if !strings.HasSuffix(trimmed, "{") {
+		return false
+	}
+	trimmed = strings.TrimSpace(strings.TrimSuffix(trimmed, "{"))
+	return trimmed == "for" || strings.HasPrefix(trimmed, "for ") || strings.HasPrefix(trimmed, "for(")
+}
+
+// knownGOOS and knownGOARCH list the GOOS/GOARCH values recognized by the page_linux.html /
+// page_linux_amd64.html filename convention, mirroring go/build's own file name matching.
+var knownGOOS = map[string]bool{
+	"linux": true, "windows": true, "darwin": true, "freebsd": true, "android": true,
+	"ios": true, "js": true, "wasip1": true, "plan9": true, "solaris": true,
+	"aix": true, "netbsd": true, "openbsd": true, "dragonfly": true,
+}
+
+var knownGOARCH = map[string]bool{
+	"amd64": true, "386": true, "arm": true, "arm64": true, "wasm": true,
+	"mips": true, "mips64": true, "ppc64": true, "riscv64": true, "s390x": true,
+}
+
+// resolveBuildConstraint determines the //go:build line for the template, either from an explicit
+// {{build "..."}} directive, the CodeGenerator.BuildConstraint field, or a page_linux.html / page_linux_amd64.html
+// style filename suffix, and returns an error if the resulting expression fails to parse. A {{build ...}}
+// directive declared in a child template that {{extend}}s a base survives into g.RootBlocks because
+// Resolver.Resolve carries child-only directive blocks through the merge, so this still finds it.
+func (g *CodeGenerator) resolveBuildConstraint() (string, error) {
+	expr := g.BuildConstraint
+	if expr == "" {
+		for _, block := range g.RootBlocks {
+			if block.BlockType == TypeBuild {
+				expr = strings.Trim(strings.TrimSpace(block.Content), `"`)
+				break
+			}
+		}
+	}
+	if expr == "" {
+		expr = buildConstraintFromFilename(g.TemplatePath)
+	}
+	if expr == "" {
+		return "", nil
+	}
+
+	line := "//go:build " + expr
+	if _, err := constraint.Parse(line); err != nil {
+		return "", fmt.Errorf("goh: invalid build constraint %q: %w", expr, err)
+	}
+	return line, nil
+}
+
+// buildConstraintFromFilename derives a build constraint expression from a page_linux.html or
+// page_linux_amd64.html style filename suffix, returning "" if the filename carries no such suffix.
+func buildConstraintFromFilename(templateFilePath string) string {
+	base := strings.TrimSuffix(path.Base(templateFilePath), path.Ext(templateFilePath))
+	parts := strings.Split(base, "_")
+
+	var goos, goarch string
+	if n := len(parts); n >= 2 && knownGOARCH[parts[n-1]] {
+		goarch = parts[n-1]
+		if n >= 3 && knownGOOS[parts[n-2]] {
+			goos = parts[n-2]
+		}
+	} else if n := len(parts); n >= 2 && knownGOOS[parts[n-1]] {
+		goos = parts[n-1]
+	}
+
+	switch {
+	case goos != "" && goarch != "":
+		return goos + " && " + goarch
+	case goos != "":
+		return goos
+	case goarch != "":
+		return goarch
+	default:
+		return ""
+	}
+}
+
+// resolveEscaper returns the EscapeMode a {{! mode: ...}} directive selects, falling back to the
+// CodeGenerator.Escaper field (EscapeHTML by default) when no directive is present or its mode name is unknown.
+// Like {{build ...}}, a {{! mode: ...}} directive declared in a child template that {{extend}}s a base survives
+// the merge in g.RootBlocks, since Resolver.Resolve carries child-only directive blocks through.
+func (g *CodeGenerator) resolveEscaper() EscapeMode {
+	for _, block := range g.RootBlocks {
+		if block.BlockType == TypeMode {
+			if mode, ok := parseEscapeMode(strings.TrimSpace(block.Content)); ok {
+				return mode
+			}
+		}
+	}
+	return g.Escaper
+}
+
+// sinkImports returns the stdlib imports required by the function signature's sink parameter, plus "context"
+// when the function takes a leading context.Context parameter.
+func (g *CodeGenerator) sinkImports() []string {
+	var imports []string
+	switch g.SinkType {
+	case SinkWriter:
+		imports = []string{"io"}
+	case SinkStringsBuilder:
+		imports = []string{"strings"}
+	default:
+		imports = []string{"bytes"}
+	}
+
+	if g.ContextName != "" {
+		imports = append(imports, "context")
+	}
+	return imports
 }
 
 // generateFunction parses a block to extract and validate a function, returning the function's code, buffer name, and any error.
@@ -134,27 +443,68 @@ func (g *CodeGenerator) generateFunction(block *Block) (code string, bufferName
 
 	lastParameter := parameters[len(parameters)-1]
 	expression := lastParameter.Type
+	pointer := false
 	if starExpr, ok := expression.(*ast.StarExpr); ok {
 		expression = starExpr.X
+		pointer = true
 	}
 	selectorExpr, ok := expression.(*ast.SelectorExpr)
 	if !ok {
-		err = errors.New("function parameters should not be empty")
+		err = fmt.Errorf("goh: function's last parameter must be %s, %s, or %s, not %s", SinkBuffer, SinkStringsBuilder, SinkWriter, types.ExprString(lastParameter.Type))
 		return
 	}
 
-	if selectorExpr.X.(*ast.Ident).Name != "bytes" || selectorExpr.Sel.Name != "Buffer" {
-		err = errors.New("function parameters should not be empty")
+	packageName := selectorExpr.X.(*ast.Ident).Name
+	var detected SinkKind
+	switch {
+	case pointer && packageName == "bytes" && selectorExpr.Sel.Name == "Buffer":
+		detected = SinkBuffer
+	case pointer && packageName == "strings" && selectorExpr.Sel.Name == "Builder":
+		detected = SinkStringsBuilder
+	case !pointer && packageName == "io" && selectorExpr.Sel.Name == "Writer":
+		detected = SinkWriter
+	default:
+		err = fmt.Errorf("goh: function's last parameter must be %s, %s, or %s, not %s", SinkBuffer, SinkStringsBuilder, SinkWriter, types.ExprString(lastParameter.Type))
 		return
 	}
 
+	if g.SinkType != SinkUnspecified && g.SinkType != detected {
+		err = fmt.Errorf("goh: CodeGenerator.SinkType is %s but the function's last parameter is %s", g.SinkType, detected)
+		return
+	}
+	g.SinkType = detected
+
 	if n := len(lastParameter.Names); n > 0 {
 		bufferName = lastParameter.Names[n-1].Name
 	}
+
 	code = block.Content
+	if g.ContextAware && functionDecl.Type.Results == nil {
+		if contextName, ok := leadingContextParam(parameters); ok {
+			g.ContextName = contextName
+			code += " error"
+		}
+	}
 	return
 }
 
+// leadingContextParam reports the parameter name of parameters' first entry if it is typed context.Context.
+func leadingContextParam(parameters []*ast.Field) (name string, ok bool) {
+	if len(parameters) < 2 {
+		return "", false
+	}
+
+	selectorExpr, ok := parameters[0].Type.(*ast.SelectorExpr)
+	if !ok || selectorExpr.X.(*ast.Ident).Name != "context" || selectorExpr.Sel.Name != "Context" {
+		return "", false
+	}
+
+	if n := len(parameters[0].Names); n > 0 {
+		return parameters[0].Names[n-1].Name, true
+	}
+	return "", false
+}
+
 // generateValue processes a block to generate the appropriate Go code for writing the block's content to the buffer.
 func (g *CodeGenerator) generateValue(block *Block) {
 	// Trim the block content
@@ -163,8 +513,42 @@ func (g *CodeGenerator) generateValue(block *Block) {
 		return
 	}
 
-	// Define a map of variable type handlers
-	varTypeHandlers := map[int]func(string, string) string{
+	// Get the handler function from the sink's handler map and generate the code
+	if handler, exists := g.valueHandlers()[block.VariableType]; exists {
+		code := handler(block.Content, g.BufferName)
+		g.Buffer.WriteString(code)
+	}
+}
+
+// valueHandlers returns the per-VariableType code generators for plain (non-escaped) output, dispatched on the
+// function's sink type: a *bytes.Buffer/*strings.Builder sink writes directly via WriteString/Write, while an
+// io.Writer sink has no WriteString method and goes through io.WriteString and the utils *W helpers instead.
+func (g *CodeGenerator) valueHandlers() map[int]func(string, string) string {
+	if g.SinkType == SinkWriter {
+		return map[int]func(string, string) string{
+			VarTypeString: func(content, bufferName string) string {
+				return fmt.Sprintf("io.WriteString(%s, %s)\n", bufferName, content)
+			},
+			VarTypeBytes: func(content, bufferName string) string {
+				return fmt.Sprintf("%s.Write(%s)\n", bufferName, content)
+			},
+			VarTypeInt: func(content, bufferName string) string {
+				return fmt.Sprintf("Goh.FormatIntW(int64(%s), %s)\n", content, bufferName)
+			},
+			VarTypeUint: func(content, bufferName string) string {
+				return fmt.Sprintf("Goh.FormatUintW(uint64(%s), %s)\n", content, bufferName)
+			},
+			VarTypeBool: func(content, bufferName string) string {
+				g.ConstantLength += 5
+				return fmt.Sprintf("Goh.FormatBoolW(%s, %s)\n", content, bufferName)
+			},
+			VarTypeAny: func(content, bufferName string) string {
+				return fmt.Sprintf("Goh.FormatAnyW(%s, %s)\n", content, bufferName)
+			},
+		}
+	}
+
+	return map[int]func(string, string) string{
 		VarTypeString: func(content, bufferName string) string {
 			// Generate code for string type
 			return fmt.Sprintf("%s.WriteString(%s)\n", bufferName, content)
@@ -191,12 +575,6 @@ func (g *CodeGenerator) generateValue(block *Block) {
 			return fmt.Sprintf("Goh.FormatAny(%s, %s)\n", content, bufferName)
 		},
 	}
-
-	// Get the handler function from the map and generate the code
-	if handler, exists := varTypeHandlers[block.VariableType]; exists {
-		code := handler(block.Content, g.BufferName)
-		g.Buffer.WriteString(code)
-	}
 }
 
 // generateEscapedHTML processes a block and generates Go code to escape HTML content based on the block's variable type.
@@ -207,37 +585,8 @@ func (g *CodeGenerator) generateEscapedHTML(block *Block) {
 		return
 	}
 
-	// Define a map of variable type handlers
-	varTypeHandlers := map[int]func(string, string) string{
-		VarTypeString: func(content, bufferName string) string {
-			// Generate code for string type
-			return fmt.Sprintf("Goh.EscapeHTML(%s, %s)\n", content, bufferName)
-		},
-		VarTypeBytes: func(content, bufferName string) string {
-			// Generate code for bytes type
-			return fmt.Sprintf("Goh.EscapeHTML(Goh.Bytes2String(%s), %s)\n", content, bufferName)
-		},
-		VarTypeInt: func(content, bufferName string) string {
-			// Generate code for int type
-			return fmt.Sprintf("Goh.FormatInt(int64(%s), %s)\n", content, bufferName)
-		},
-		VarTypeUint: func(content, bufferName string) string {
-			// Generate code for uint type
-			return fmt.Sprintf("Goh.FormatUint(uint64(%s), %s)\n", content, bufferName)
-		},
-		VarTypeBool: func(content, bufferName string) string {
-			// Generate code for bool type and update constant length
-			g.ConstantLength += 5
-			return fmt.Sprintf("Goh.FormatBool(%s, %s)\n", content, bufferName)
-		},
-		VarTypeAny: func(content, bufferName string) string {
-			// Generate code for any type
-			return fmt.Sprintf("Goh.FormatAny(%s, %s)\n", content, bufferName)
-		},
-	}
-
-	// Get the handler function from the map and generate the code
-	if handler, exists := varTypeHandlers[block.VariableType]; exists {
+	// Get the handler function from the sink's handler map and generate the code
+	if handler, exists := g.escapedHandlers()[block.VariableType]; exists {
 		generatedCode := handler(block.Content, g.BufferName)
 		g.Buffer.WriteString(generatedCode)
 	} else {
@@ -245,3 +594,30 @@ func (g *CodeGenerator) generateEscapedHTML(block *Block) {
 		panic(fmt.Sprintf("Unsupported value type: %d", block.VariableType))
 	}
 }
+
+// escapedHandlers returns the per-VariableType code generators for escaped output. Numeric and bool values never
+// need escaping, so those handlers are shared with valueHandlers; only the string/bytes handlers are replaced
+// with a call into the escaper selected by g.escaper (EscapeHTML by default), dispatched on sink type the same
+// way valueHandlers is.
+func (g *CodeGenerator) escapedHandlers() map[int]func(string, string) string {
+	handlers := g.valueHandlers()
+	escapeFunc := g.escaper.funcName()
+
+	if g.SinkType == SinkWriter {
+		handlers[VarTypeString] = func(content, bufferName string) string {
+			return fmt.Sprintf("Goh.%sW(%s, %s)\n", escapeFunc, content, bufferName)
+		}
+		handlers[VarTypeBytes] = func(content, bufferName string) string {
+			return fmt.Sprintf("Goh.%sW(Goh.Bytes2String(%s), %s)\n", escapeFunc, content, bufferName)
+		}
+		return handlers
+	}
+
+	handlers[VarTypeString] = func(content, bufferName string) string {
+		return fmt.Sprintf("Goh.%s(%s, %s)\n", escapeFunc, content, bufferName)
+	}
+	handlers[VarTypeBytes] = func(content, bufferName string) string {
+		return fmt.Sprintf("Goh.%s(Goh.Bytes2String(%s), %s)\n", escapeFunc, content, bufferName)
+	}
+	return handlers
+}
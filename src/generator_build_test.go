@@ -0,0 +1,44 @@
+package Goh
+
+import "testing"
+
+func TestBuildConstraintFromFilename(t *testing.T) {
+	cases := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"no suffix", "page.html", ""},
+		{"goos only", "page_linux.html", "linux"},
+		{"goos and goarch", "page_linux_amd64.html", "linux && amd64"},
+		{"goarch only", "page_amd64.html", "amd64"},
+		{"unknown suffix left alone", "page_staging.html", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := buildConstraintFromFilename(tc.path); got != tc.want {
+				t.Errorf("buildConstraintFromFilename(%q) = %q, want %q", tc.path, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResolveBuildConstraintRejectsInvalidExpression(t *testing.T) {
+	g := &CodeGenerator{BuildConstraint: "linux &&"}
+
+	if _, err := g.resolveBuildConstraint(); err == nil {
+		t.Fatal("resolveBuildConstraint() error = nil, want an error for a malformed build expression")
+	}
+}
+
+func TestResolveBuildConstraintAcceptsValidExpression(t *testing.T) {
+	g := &CodeGenerator{BuildConstraint: "linux && amd64"}
+
+	line, err := g.resolveBuildConstraint()
+	if err != nil {
+		t.Fatalf("resolveBuildConstraint() error = %v", err)
+	}
+	if want := "//go:build linux && amd64"; line != want {
+		t.Errorf("resolveBuildConstraint() = %q, want %q", line, want)
+	}
+}
@@ -0,0 +1,62 @@
+package Goh
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestGenerateCodeStringsBuilderSink verifies that a SinkStringsBuilder render function containing both an
+// {{int}} value and an HTML-escaped {{string}} value generates syntactically valid Go that routes through the
+// io.StringWriter-typed utils helpers (Goh.FormatInt, Goh.EscapeHTML), not the io.Writer-only *W variants, since
+// the generator reserves the *W variants for SinkWriter and *strings.Builder implements io.StringWriter.
+func TestGenerateCodeStringsBuilderSink(t *testing.T) {
+	out, err := os.CreateTemp(t.TempDir(), "builder_sink_*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+
+	g := &CodeGenerator{
+		DefinedFunction: &Block{
+			BlockType: TypeCode,
+			Content:   "func Render(sb *strings.Builder)",
+		},
+		RootBlocks: Blocks{
+			{BlockType: TypeValue, VariableType: VarTypeInt, Content: "count"},
+			{BlockType: TypeEscape, VariableType: VarTypeString, Content: "name"},
+		},
+		OutputFile:  out,
+		Buffer:      bytes.NewBuffer(nil),
+		PackageName: "template",
+		SinkType:    SinkStringsBuilder,
+	}
+
+	if err := g.GenerateCode(); err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	generated, err := os.ReadFile(out.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), out.Name(), generated, 0); err != nil {
+		t.Fatalf("generated code is not valid Go: %v\n%s", err, generated)
+	}
+
+	src := string(generated)
+	for _, want := range []string{"Goh.FormatInt(int64(count), sb)", "Goh.EscapeHTML(name, sb)"} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated code missing %q:\n%s", want, src)
+		}
+	}
+	for _, unwanted := range []string{"FormatIntW", "EscapeHTMLW"} {
+		if strings.Contains(src, unwanted) {
+			t.Errorf("generated code unexpectedly uses the io.Writer-only %s for a SinkStringsBuilder sink:\n%s", unwanted, src)
+		}
+	}
+}
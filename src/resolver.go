@@ -0,0 +1,108 @@
+package Goh
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Resolver turns a template's {{extend "base.html"}} directive into a single, flattened block stream by loading
+// the referenced base template, collecting the current template's named {{block "name"}}...{{end}} regions, and
+// substituting them into the base template's blocks in place of its blocks with the same name. It sits between
+// Parser and CodeGenerator.
+type Resolver struct {
+	TemplateDir string
+	visiting    map[string]bool
+}
+
+// Resolve returns blocks, rawCode and definedFunc unchanged if blocks contains no TypeExtend directive.
+// Otherwise it loads the extended template, merges in the named overrides declared in blocks, and returns the
+// merged stream. The render function comes from whichever template actually defines one: a child that only
+// declares {{extend}} plus block overrides has no {{func}} of its own, so definedFunc/rawCode fall back to the
+// base layout's in that case. It detects extend cycles by tracking the chain of template paths being resolved.
+func (r *Resolver) Resolve(blocks Blocks, rawCode string, definedFunc *Block, templatePath string) (Blocks, string, *Block, error) {
+	var extend *Block
+	for _, block := range blocks {
+		if block.BlockType == TypeExtend {
+			extend = block
+			break
+		}
+	}
+	if extend == nil {
+		return blocks, rawCode, definedFunc, nil
+	}
+
+	if r.visiting == nil {
+		r.visiting = make(map[string]bool)
+	}
+	if r.visiting[templatePath] {
+		return nil, "", nil, fmt.Errorf("goh: extend cycle detected at %q", templatePath)
+	}
+	r.visiting[templatePath] = true
+	defer delete(r.visiting, templatePath)
+
+	baseName := strings.Trim(strings.TrimSpace(extend.Content), `"`)
+	basePath := path.Join(r.TemplateDir, baseName)
+	if r.visiting[basePath] {
+		return nil, "", nil, fmt.Errorf("goh: extend cycle detected at %q", basePath)
+	}
+
+	baseBlocks, baseRawCode, baseDefinedFunc := (&Parser{}).Parse(basePath)
+	baseBlocks, baseRawCode, baseDefinedFunc, err := r.Resolve(baseBlocks, baseRawCode, baseDefinedFunc, basePath)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	overrides := make(map[string]*Block)
+	var directives []*Block
+	for _, block := range blocks {
+		switch {
+		case block.BlockType == TypeBlock && block.Name != "":
+			overrides[block.Name] = block
+		case block.BlockType == TypeBuild || block.BlockType == TypeMode:
+			// {{build ...}}/{{! mode: ...}} directives describe the child template itself, so they must
+			// survive the merge even though they have no named counterpart in the base to substitute into.
+			directives = append(directives, block)
+		}
+	}
+
+	merged, err := baseBlocks.Merge(overrides)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	merged = append(directives, merged...)
+
+	if definedFunc == nil {
+		definedFunc = baseDefinedFunc
+		rawCode = baseRawCode
+	}
+
+	return merged, rawCode, definedFunc, nil
+}
+
+// Merge returns a copy of parent with each named {{block "name"}} region replaced by the override of the same
+// name, if one is supplied. It returns an error if an override does not match any named block in parent, since
+// an override that never applies almost always indicates a typo in the child template.
+func (parent Blocks) Merge(overrides map[string]*Block) (Blocks, error) {
+	merged := make(Blocks, 0, len(parent))
+	matched := make(map[string]bool, len(overrides))
+
+	for _, block := range parent {
+		if block.BlockType == TypeBlock && block.Name != "" {
+			if override, ok := overrides[block.Name]; ok {
+				merged = append(merged, override)
+				matched[block.Name] = true
+				continue
+			}
+		}
+		merged = append(merged, block)
+	}
+
+	for name := range overrides {
+		if !matched[name] {
+			return nil, fmt.Errorf("goh: block %q does not match any block in the extended template", name)
+		}
+	}
+
+	return merged, nil
+}
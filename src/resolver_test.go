@@ -0,0 +1,52 @@
+package Goh
+
+import "testing"
+
+func TestResolverResolveDetectsCycle(t *testing.T) {
+	r := &Resolver{TemplateDir: ".", visiting: map[string]bool{"base.html": true}}
+	blocks := Blocks{{BlockType: TypeExtend, Content: `"base.html"`}}
+
+	_, _, _, err := r.Resolve(blocks, "", nil, "child.html")
+	if err == nil {
+		t.Fatal("Resolve() error = nil, want an extend-cycle error")
+	}
+}
+
+func TestResolverResolveNoExtendReturnsUnchanged(t *testing.T) {
+	r := &Resolver{TemplateDir: "."}
+	blocks := Blocks{{BlockType: TypeHTML, Content: "hello"}}
+	definedFunc := &Block{BlockType: TypeCode, Content: "func Render(buf *bytes.Buffer)"}
+
+	gotBlocks, gotRawCode, gotDefinedFunc, err := r.Resolve(blocks, "raw", definedFunc, "page.html")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(gotBlocks) != 1 || gotRawCode != "raw" || gotDefinedFunc != definedFunc {
+		t.Errorf("Resolve() with no {{extend}} should return its inputs unchanged, got blocks=%v rawCode=%q definedFunc=%v", gotBlocks, gotRawCode, gotDefinedFunc)
+	}
+}
+
+func TestBlocksMergeReplacesNamedOverride(t *testing.T) {
+	parent := Blocks{
+		{BlockType: TypeBlock, Name: "header", Content: "base header"},
+		{BlockType: TypeHTML, Content: "body"},
+	}
+	override := &Block{BlockType: TypeBlock, Name: "header", Content: "child header"}
+
+	merged, err := parent.Merge(map[string]*Block{"header": override})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if len(merged) != 2 || merged[0] != override {
+		t.Errorf("Merge() = %v, want override substituted in place", merged)
+	}
+}
+
+func TestBlocksMergeUnmatchedOverrideErrors(t *testing.T) {
+	parent := Blocks{{BlockType: TypeHTML, Content: "body"}}
+	override := &Block{BlockType: TypeBlock, Name: "sidebar", Content: "child sidebar"}
+
+	if _, err := parent.Merge(map[string]*Block{"sidebar": override}); err == nil {
+		t.Fatal("Merge() error = nil, want an error for an override with no matching block in parent")
+	}
+}
@@ -0,0 +1,91 @@
+package Goh
+
+import (
+	"io"
+	"net/url"
+	"strings"
+)
+
+// EscapeJS escapes s for embedding in a JavaScript string literal and writes the result to w.
+func EscapeJS(s string, w io.StringWriter) {
+	w.WriteString(escapeJS(s))
+}
+
+// EscapeURL percent-encodes s for embedding in a URL query and writes the result to w.
+func EscapeURL(s string, w io.StringWriter) {
+	w.WriteString(url.QueryEscape(s))
+}
+
+// EscapeCSVField quotes s per RFC 4180 if it contains a comma, quote, or newline, and writes the result to w.
+func EscapeCSVField(s string, w io.StringWriter) {
+	w.WriteString(escapeCSVField(s))
+}
+
+// EscapeText writes s to w unmodified. It is the no-op escaper for plain-text output, the text/template
+// counterpart of EscapeHTML.
+func EscapeText(s string, w io.StringWriter) {
+	w.WriteString(s)
+}
+
+// EscapeJSW is the io.Writer counterpart of EscapeJS, used when CodeGenerator.SinkType is SinkWriter.
+func EscapeJSW(s string, w io.Writer) {
+	io.WriteString(w, escapeJS(s))
+}
+
+// EscapeURLW is the io.Writer counterpart of EscapeURL, used when CodeGenerator.SinkType is SinkWriter.
+func EscapeURLW(s string, w io.Writer) {
+	io.WriteString(w, url.QueryEscape(s))
+}
+
+// EscapeCSVFieldW is the io.Writer counterpart of EscapeCSVField, used when CodeGenerator.SinkType is SinkWriter.
+func EscapeCSVFieldW(s string, w io.Writer) {
+	io.WriteString(w, escapeCSVField(s))
+}
+
+// EscapeTextW is the io.Writer counterpart of EscapeText, used when CodeGenerator.SinkType is SinkWriter.
+func EscapeTextW(s string, w io.Writer) {
+	io.WriteString(w, s)
+}
+
+// escapeJS escapes the characters that would otherwise break out of a JavaScript string literal, close the
+// surrounding <script> tag if the literal is later embedded in HTML, or (U+2028/U+2029) terminate the statement
+// outright, since both are valid line terminators inside a JS string literal despite being unescaped JSON/Go text.
+func escapeJS(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\'':
+			b.WriteString(`\'`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '<':
+			b.WriteString(`\u003c`)
+		case '>':
+			b.WriteString(`\u003e`)
+		case '&':
+			b.WriteString(`\u0026`)
+		case ' ':
+			b.WriteString(`\u2028`)
+		case ' ':
+			b.WriteString(`\u2029`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// escapeCSVField quotes s per RFC 4180 when it contains a character that is otherwise significant to a CSV
+// parser, doubling any quotes already in the field.
+func escapeCSVField(s string) string {
+	if strings.ContainsAny(s, ",\"\n\r") {
+		return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	}
+	return s
+}
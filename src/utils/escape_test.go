@@ -0,0 +1,59 @@
+package Goh
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEscapeJS(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"backslash", `a\b`, `a\\b`},
+		{"quotes", `"it's"`, `\"it\'s\"`},
+		{"newlines", "a\nb\rc", `a\nb\rc`},
+		{"script breakout", "</script>", `\u003c/script\u003e`},
+		{"ampersand", "a&b", `a\u0026b`},
+		{"line separator", "a b", `a\u2028b`},
+		{"paragraph separator", "a b", `a\u2029b`},
+		{"plain", "hello", "hello"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := escapeJS(tc.in); got != tc.want {
+				t.Errorf("escapeJS(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEscapeJSWritesToStringWriter(t *testing.T) {
+	var b strings.Builder
+	EscapeJS(" ", &b)
+	if b.String() != `\u2028` {
+		t.Errorf("EscapeJS wrote %q, want %q", b.String(), `\u2028`)
+	}
+}
+
+func TestEscapeCSVField(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "hello", "hello"},
+		{"comma", "a,b", `"a,b"`},
+		{"quote", `a"b`, `"a""b"`},
+		{"newline", "a\nb", "\"a\nb\""},
+		{"carriage return", "a\rb", "\"a\rb\""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := escapeCSVField(tc.in); got != tc.want {
+				t.Errorf("escapeCSVField(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
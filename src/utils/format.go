@@ -0,0 +1,42 @@
+package Goh
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+)
+
+// FormatInt writes v's decimal representation to w. w is typed as io.StringWriter rather than *bytes.Buffer so
+// that the same call works whether CodeGenerator.SinkType is SinkBuffer or SinkStringsBuilder, since both
+// *bytes.Buffer and *strings.Builder implement WriteString. Use FormatIntW instead when SinkType is SinkWriter.
+func FormatInt(v int64, w io.StringWriter) {
+	w.WriteString(strconv.FormatInt(v, 10))
+}
+
+// FormatUint writes v's decimal representation to w. See FormatInt for why w is io.StringWriter.
+func FormatUint(v uint64, w io.StringWriter) {
+	w.WriteString(strconv.FormatUint(v, 10))
+}
+
+// FormatBool writes "true" or "false" to w. See FormatInt for why w is io.StringWriter.
+func FormatBool(v bool, w io.StringWriter) {
+	w.WriteString(strconv.FormatBool(v))
+}
+
+// FormatAny writes v's default string representation to w. See FormatInt for why w is io.StringWriter.
+func FormatAny(v any, w io.StringWriter) {
+	w.WriteString(fmt.Sprint(v))
+}
+
+// EscapeHTML HTML-escapes s and writes the result to w. See FormatInt for why w is io.StringWriter. It is the
+// default escaper; EscapeJS/EscapeURL/EscapeCSVField/EscapeText cover the other {{! mode: ...}} choices.
+func EscapeHTML(s string, w io.StringWriter) {
+	w.WriteString(html.EscapeString(s))
+}
+
+// Bytes2String converts b to a string by copying it, for the []byte values passed through the escaped-output
+// handlers, whose escapers take a string.
+func Bytes2String(b []byte) string {
+	return string(b)
+}
@@ -0,0 +1,39 @@
+// Package Goh holds the runtime helpers generated template code calls into.
+package Goh
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+)
+
+// FormatIntW writes v's decimal representation to w. It is the io.Writer counterpart of FormatInt, used when
+// CodeGenerator.SinkType is SinkWriter.
+func FormatIntW(v int64, w io.Writer) {
+	io.WriteString(w, strconv.FormatInt(v, 10))
+}
+
+// FormatUintW writes v's decimal representation to w. It is the io.Writer counterpart of FormatUint, used when
+// CodeGenerator.SinkType is SinkWriter.
+func FormatUintW(v uint64, w io.Writer) {
+	io.WriteString(w, strconv.FormatUint(v, 10))
+}
+
+// FormatBoolW writes "true" or "false" to w. It is the io.Writer counterpart of FormatBool, used when
+// CodeGenerator.SinkType is SinkWriter.
+func FormatBoolW(v bool, w io.Writer) {
+	io.WriteString(w, strconv.FormatBool(v))
+}
+
+// FormatAnyW writes v's default string representation to w. It is the io.Writer counterpart of FormatAny, used
+// when CodeGenerator.SinkType is SinkWriter.
+func FormatAnyW(v any, w io.Writer) {
+	fmt.Fprint(w, v)
+}
+
+// EscapeHTMLW HTML-escapes s and writes the result to w. It is the io.Writer counterpart of EscapeHTML, used
+// when CodeGenerator.SinkType is SinkWriter.
+func EscapeHTMLW(s string, w io.Writer) {
+	io.WriteString(w, html.EscapeString(s))
+}